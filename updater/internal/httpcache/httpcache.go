@@ -0,0 +1,189 @@
+// Package httpcache is an http.RoundTripper that makes conditional GET
+// requests against a small on-disk cache, so repeat refreshes don't
+// re-download version manifests and build metadata that haven't changed.
+package httpcache
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultPath returns the default location of the cache file, for callers
+// that don't have a more specific path to pass to Load.
+func DefaultPath() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "rustnesscloud-manifest", "http.json"), nil
+}
+
+type entry struct {
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"lastModified,omitempty"`
+	BodyHash     string    `json:"bodyHash"`
+	Body         []byte    `json:"body"`
+	FetchedAt    time.Time `json:"fetchedAt"`
+}
+
+// Cache holds cached HTTP responses keyed by request URL and persists
+// them to a JSON file on disk.
+type Cache struct {
+	path    string
+	noCache bool
+	maxAge  time.Duration
+
+	mu      sync.Mutex
+	entries map[string]entry
+
+	served    int64
+	refreshed int64
+}
+
+// Load reads path, if it exists, into a Cache ready to wrap an
+// http.RoundTripper. maxAge, if positive, lets a request skip the
+// network entirely when its cached copy is younger than maxAge.
+// noCache disables both reading and writing the cache.
+func Load(path string, noCache bool, maxAge time.Duration) (*Cache, error) {
+	c := &Cache{path: path, noCache: noCache, maxAge: maxAge, entries: map[string]entry{}}
+	if noCache {
+		return c, nil
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &c.entries); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Save writes the cache back to disk, creating its parent directory if
+// needed. It's a no-op when the cache was loaded with noCache.
+func (c *Cache) Save() error {
+	if c.noCache {
+		return nil
+	}
+	c.mu.Lock()
+	data, err := json.MarshalIndent(c.entries, "", "  ")
+	c.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(c.path, data, 0o644)
+}
+
+// Stats returns how many requests have been served straight from cache
+// (max-age hit or 304 Not Modified) versus actually refreshed (200 OK)
+// since the last ResetStats call.
+func (c *Cache) Stats() (served, refreshed int64) {
+	return atomic.LoadInt64(&c.served), atomic.LoadInt64(&c.refreshed)
+}
+
+// ResetStats zeroes the counters Stats reports, so callers can attribute
+// a batch of requests (e.g. one project's refresh) to its own summary.
+func (c *Cache) ResetStats() {
+	atomic.StoreInt64(&c.served, 0)
+	atomic.StoreInt64(&c.refreshed, 0)
+}
+
+// Transport wraps base in a RoundTripper that conditionally GETs through
+// c. Only GET requests are cached; everything else passes through.
+func (c *Cache) Transport(base http.RoundTripper) http.RoundTripper {
+	return &transport{base: base, cache: c}
+}
+
+type transport struct {
+	base  http.RoundTripper
+	cache *Cache
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet || t.cache.noCache {
+		return t.base.RoundTrip(req)
+	}
+
+	key := req.URL.String()
+	t.cache.mu.Lock()
+	cached, ok := t.cache.entries[key]
+	t.cache.mu.Unlock()
+
+	if ok && t.cache.maxAge > 0 && time.Since(cached.FetchedAt) < t.cache.maxAge {
+		atomic.AddInt64(&t.cache.served, 1)
+		return cached.asResponse(req), nil
+	}
+
+	if ok {
+		if cached.ETag != "" {
+			req.Header.Set("If-None-Match", cached.ETag)
+		}
+		if cached.LastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.LastModified)
+		}
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if ok && resp.StatusCode == http.StatusNotModified {
+		resp.Body.Close()
+		atomic.AddInt64(&t.cache.served, 1)
+		return cached.asResponse(req), nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return resp, nil
+	}
+	atomic.AddInt64(&t.cache.refreshed, 1)
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(body)
+	t.cache.mu.Lock()
+	t.cache.entries[key] = entry{
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+		BodyHash:     hex.EncodeToString(sum[:]),
+		Body:         body,
+		FetchedAt:    time.Now(),
+	}
+	t.cache.mu.Unlock()
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
+
+func (e entry) asResponse(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,152 @@
+package httpcache
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestTransport_ConditionalGET_NotModified(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer srv.Close()
+
+	cache, err := Load(filepath.Join(t.TempDir(), "http.json"), false, 0)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	client := &http.Client{Transport: cache.Transport(http.DefaultTransport)}
+
+	for i := 0; i < 2; i++ {
+		resp, err := client.Get(srv.URL)
+		if err != nil {
+			t.Fatalf("Get #%d: %v", i, err)
+		}
+		resp.Body.Close()
+	}
+	if requests != 2 {
+		t.Fatalf("server saw %d requests, want 2 (both conditional GETs reach it)", requests)
+	}
+	served, refreshed := cache.Stats()
+	if served != 1 || refreshed != 1 {
+		t.Fatalf("Stats() = served %d, refreshed %d, want 1, 1", served, refreshed)
+	}
+}
+
+func TestTransport_MaxAge_SkipsNetwork(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer srv.Close()
+
+	cache, err := Load(filepath.Join(t.TempDir(), "http.json"), false, time.Hour)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	client := &http.Client{Transport: cache.Transport(http.DefaultTransport)}
+
+	for i := 0; i < 3; i++ {
+		resp, err := client.Get(srv.URL)
+		if err != nil {
+			t.Fatalf("Get #%d: %v", i, err)
+		}
+		resp.Body.Close()
+	}
+	if requests != 1 {
+		t.Fatalf("server saw %d requests, want 1 (later ones served from cache under max-age)", requests)
+	}
+	served, refreshed := cache.Stats()
+	if served != 2 || refreshed != 1 {
+		t.Fatalf("Stats() = served %d, refreshed %d, want 2, 1", served, refreshed)
+	}
+}
+
+func TestCache_SaveAndLoad_Persists(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "http.json")
+
+	cache, err := Load(path, false, 0)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	client := &http.Client{Transport: cache.Transport(http.DefaultTransport)}
+	resp, err := client.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	resp.Body.Close()
+	if err := cache.Save(); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	reloaded, err := Load(path, false, 0)
+	if err != nil {
+		t.Fatalf("Load after Save: %v", err)
+	}
+	client = &http.Client{Transport: reloaded.Transport(http.DefaultTransport)}
+	resp, err = client.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("Get after reload: %v", err)
+	}
+	resp.Body.Close()
+
+	if requests != 2 {
+		t.Fatalf("server saw %d requests, want 2 (second process still conditional-GETs)", requests)
+	}
+	served, _ := reloaded.Stats()
+	if served != 1 {
+		t.Fatalf("reloaded cache recognized 304, served = %d, want 1", served)
+	}
+}
+
+func TestCache_NoCache_BypassesEntirely(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer srv.Close()
+
+	cache, err := Load(filepath.Join(t.TempDir(), "http.json"), true, 0)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	client := &http.Client{Transport: cache.Transport(http.DefaultTransport)}
+	for i := 0; i < 2; i++ {
+		resp, err := client.Get(srv.URL)
+		if err != nil {
+			t.Fatalf("Get #%d: %v", i, err)
+		}
+		resp.Body.Close()
+	}
+	if requests != 2 {
+		t.Fatalf("server saw %d requests, want 2 (noCache never short-circuits)", requests)
+	}
+	served, refreshed := cache.Stats()
+	if served != 0 || refreshed != 0 {
+		t.Fatalf("Stats() = served %d, refreshed %d, want 0, 0 (noCache doesn't track stats)", served, refreshed)
+	}
+}
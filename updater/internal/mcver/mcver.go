@@ -0,0 +1,139 @@
+// Package mcver parses and orders Minecraft-style version strings.
+//
+// It understands ordinary releases (1.21, 1.21.1), pre-release/candidate
+// suffixes (1.20.4-rc1, 1.16-pre1) and the weekly snapshot naming scheme
+// (24w14a), and lets callers sort a mix of these by actual release order
+// instead of plain string comparison.
+package mcver
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+var (
+	releaseRe  = regexp.MustCompile(`^(\d+)\.(\d+)(?:\.(\d+))?$`)
+	preRe      = regexp.MustCompile(`^(\d+)\.(\d+)(?:\.(\d+))?-(pre|rc)\.?(\d+)$`)
+	snapshotRe = regexp.MustCompile(`^(\d{2})w(\d{2})([a-z])$`)
+)
+
+// Version is a parsed, comparable representation of a Minecraft version
+// string. The zero value is not meaningful on its own; use Parse.
+type Version struct {
+	Raw string
+
+	// Populated for ordinary releases and pre-releases.
+	Major, Minor, Patch int
+	PreTag              string // "pre", "rc", or "" for a final release
+	PreNum              int
+
+	// Populated instead of the above for weekly snapshots (e.g. 24w14a).
+	IsSnapshot bool
+	SnapYear   int
+	SnapWeek   int
+	SnapRev    string
+}
+
+// Parse turns a Minecraft version string into a Version. It returns an
+// error for strings that don't match any known scheme (old alpha/beta
+// identifiers like "b1.7.3" included) so callers can decide how to treat
+// the oddballs rather than silently misordering them.
+func Parse(s string) (Version, error) {
+	if m := snapshotRe.FindStringSubmatch(s); m != nil {
+		year, _ := strconv.Atoi(m[1])
+		week, _ := strconv.Atoi(m[2])
+		return Version{Raw: s, IsSnapshot: true, SnapYear: year, SnapWeek: week, SnapRev: m[3]}, nil
+	}
+	if m := preRe.FindStringSubmatch(s); m != nil {
+		major, _ := strconv.Atoi(m[1])
+		minor, _ := strconv.Atoi(m[2])
+		patch := 0
+		if m[3] != "" {
+			patch, _ = strconv.Atoi(m[3])
+		}
+		preNum, _ := strconv.Atoi(m[5])
+		return Version{Raw: s, Major: major, Minor: minor, Patch: patch, PreTag: m[4], PreNum: preNum}, nil
+	}
+	if m := releaseRe.FindStringSubmatch(s); m != nil {
+		major, _ := strconv.Atoi(m[1])
+		minor, _ := strconv.Atoi(m[2])
+		patch := 0
+		if m[3] != "" {
+			patch, _ = strconv.Atoi(m[3])
+		}
+		return Version{Raw: s, Major: major, Minor: minor, Patch: patch}, nil
+	}
+	return Version{}, fmt.Errorf("mcver: unrecognized version format %q", s)
+}
+
+// preRank orders pre-release tags older-to-newer, with a final release
+// (no tag) always ranking above any pre-release of the same number.
+func preRank(tag string) int {
+	switch tag {
+	case "pre":
+		return 0
+	case "rc":
+		return 1
+	default:
+		return 2 // final release
+	}
+}
+
+// Less reports whether v is an older version than o. Two ordinary
+// releases/pre-releases compare by major, minor, patch and then
+// pre-release rank; two snapshots compare by year, week and revision
+// letter. A snapshot is considered newer than any numbered release,
+// since it previews the release that follows the current one.
+func (v Version) Less(o Version) bool {
+	if v.IsSnapshot != o.IsSnapshot {
+		return o.IsSnapshot
+	}
+	if v.IsSnapshot {
+		if v.SnapYear != o.SnapYear {
+			return v.SnapYear < o.SnapYear
+		}
+		if v.SnapWeek != o.SnapWeek {
+			return v.SnapWeek < o.SnapWeek
+		}
+		return v.SnapRev < o.SnapRev
+	}
+	if v.Major != o.Major {
+		return v.Major < o.Major
+	}
+	if v.Minor != o.Minor {
+		return v.Minor < o.Minor
+	}
+	if v.Patch != o.Patch {
+		return v.Patch < o.Patch
+	}
+	if vr, or := preRank(v.PreTag), preRank(o.PreTag); vr != or {
+		return vr < or
+	}
+	return v.PreNum < o.PreNum
+}
+
+// SortDescending sorts version strings newest-first using Parse and
+// Less. Strings that fail to parse are pushed to the end, since their
+// release order can't be determined, and ordered among themselves by
+// plain reverse string comparison.
+func SortDescending(versions []string) {
+	parsed := make(map[string]Version, len(versions))
+	ok := make(map[string]bool, len(versions))
+	for _, raw := range versions {
+		v, err := Parse(raw)
+		parsed[raw] = v
+		ok[raw] = err == nil
+	}
+	sort.SliceStable(versions, func(i, j int) bool {
+		a, b := versions[i], versions[j]
+		if ok[a] != ok[b] {
+			return ok[a] // parsable versions sort before unparsable ones
+		}
+		if !ok[a] {
+			return a > b // stable fallback for the unparsable tail
+		}
+		return parsed[b].Less(parsed[a])
+	})
+}
@@ -0,0 +1,70 @@
+package mcver
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		in   string
+		want Version
+	}{
+		{"1.7.10", Version{Raw: "1.7.10", Major: 1, Minor: 7, Patch: 10}},
+		{"1.9", Version{Raw: "1.9", Major: 1, Minor: 9}},
+		{"1.20.4-rc1", Version{Raw: "1.20.4-rc1", Major: 1, Minor: 20, Patch: 4, PreTag: "rc", PreNum: 1}},
+		{"24w14a", Version{Raw: "24w14a", IsSnapshot: true, SnapYear: 24, SnapWeek: 14, SnapRev: "a"}},
+	}
+	for _, c := range cases {
+		got, err := Parse(c.in)
+		if err != nil {
+			t.Fatalf("Parse(%q) returned error: %v", c.in, err)
+		}
+		if got != c.want {
+			t.Errorf("Parse(%q) = %+v, want %+v", c.in, got, c.want)
+		}
+	}
+}
+
+func TestParse_Unrecognized(t *testing.T) {
+	if _, err := Parse("b1.7.3"); err == nil {
+		t.Fatal("Parse(\"b1.7.3\") expected an error, got none")
+	}
+}
+
+func TestLess(t *testing.T) {
+	cases := []struct {
+		older, newer string
+	}{
+		{"1.7.10", "1.8"},
+		{"1.9", "1.10"},
+		{"1.20.4-rc1", "1.20.4"},
+	}
+	for _, c := range cases {
+		older, err := Parse(c.older)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", c.older, err)
+		}
+		newer, err := Parse(c.newer)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", c.newer, err)
+		}
+		if !older.Less(newer) {
+			t.Errorf("expected %q < %q", c.older, c.newer)
+		}
+		if newer.Less(older) {
+			t.Errorf("did not expect %q < %q", c.newer, c.older)
+		}
+	}
+}
+
+func TestSortDescending(t *testing.T) {
+	versions := []string{"1.7.10", "1.10", "1.9", "1.20.4-rc1", "1.20.4"}
+	SortDescending(versions)
+	want := []string{"1.20.4", "1.20.4-rc1", "1.10", "1.9", "1.7.10"}
+	if len(versions) != len(want) {
+		t.Fatalf("SortDescending produced %v, want %v", versions, want)
+	}
+	for i := range want {
+		if versions[i] != want[i] {
+			t.Fatalf("SortDescending produced %v, want %v", versions, want)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+// Package mirror uploads new or changed artifacts to an S3-compatible
+// bucket (MinIO, R2, Backblaze, ...) and rewrites their Entry.URL to the
+// mirror, so consumers never have to hit the original upstream host.
+package mirror
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+
+	"github.com/ezTxmMC/rustnesscloud-manifest/updater/providers"
+)
+
+// Config points a Mirror at an S3-compatible bucket.
+type Config struct {
+	Endpoint  string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	UseSSL    bool
+
+	// PublicURL is the base URL artifacts are reachable at once uploaded,
+	// e.g. "https://cdn.example.com" or "https://minio.internal/my-bucket".
+	PublicURL string
+}
+
+// ConfigFromEnv reads Config from MIRROR_* environment variables. ok is
+// false when MIRROR_ENDPOINT isn't set at all, meaning mirroring wasn't
+// requested; err is only non-nil when mirroring was requested but the
+// configuration is incomplete or malformed.
+func ConfigFromEnv() (cfg Config, ok bool, err error) {
+	cfg.Endpoint = os.Getenv("MIRROR_ENDPOINT")
+	if cfg.Endpoint == "" {
+		return Config{}, false, nil
+	}
+	cfg.Bucket = os.Getenv("MIRROR_BUCKET")
+	cfg.AccessKey = os.Getenv("MIRROR_ACCESS_KEY")
+	cfg.SecretKey = os.Getenv("MIRROR_SECRET_KEY")
+	cfg.PublicURL = strings.TrimRight(os.Getenv("MIRROR_PUBLIC_URL"), "/")
+	if cfg.Bucket == "" || cfg.PublicURL == "" {
+		return Config{}, false, fmt.Errorf("mirror: MIRROR_ENDPOINT is set but MIRROR_BUCKET or MIRROR_PUBLIC_URL is missing")
+	}
+	cfg.UseSSL, err = parseBoolEnv("MIRROR_USE_SSL", true)
+	if err != nil {
+		return Config{}, false, err
+	}
+	return cfg, true, nil
+}
+
+func parseBoolEnv(name string, def bool) (bool, error) {
+	raw := os.Getenv(name)
+	if raw == "" {
+		return def, nil
+	}
+	v, err := strconv.ParseBool(raw)
+	if err != nil {
+		return false, fmt.Errorf("mirror: %s: %w", name, err)
+	}
+	return v, nil
+}
+
+// Mirror uploads artifacts to cfg's bucket under content-addressed keys.
+type Mirror struct {
+	client    *minio.Client
+	bucket    string
+	publicURL string
+}
+
+// New connects to the bucket described by cfg. It doesn't verify the
+// bucket exists; the first Put call will fail if it doesn't.
+func New(cfg Config) (*Mirror, error) {
+	client, err := minio.New(cfg.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(cfg.AccessKey, cfg.SecretKey, ""),
+		Secure: cfg.UseSSL,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Mirror{client: client, bucket: cfg.Bucket, publicURL: cfg.PublicURL}, nil
+}
+
+// Put uploads entry's artifact to the mirror, if it isn't there already,
+// and rewrites entry in place: URL becomes the mirror's public URL and
+// the original is preserved in UpstreamURL. entry.SHA256 must already be
+// populated (e.g. via -verify), since it's both the integrity check and
+// the object key. entry is left untouched if anything goes wrong.
+func (m *Mirror) Put(ctx context.Context, client *http.Client, project, version string, entry *providers.Entry) error {
+	if entry.SHA256 == "" {
+		return fmt.Errorf("mirror: %s %s has no SHA256 to key the upload by (run with -verify)", project, version)
+	}
+	key := fmt.Sprintf("%s/%s/%s.jar", project, version, entry.SHA256)
+
+	if stat, err := m.client.StatObject(ctx, m.bucket, key, minio.StatObjectOptions{}); err == nil {
+		if entry.Size == 0 || stat.Size == entry.Size {
+			entry.UpstreamURL = entry.URL
+			entry.URL = m.publicURL + "/" + key
+			return nil
+		}
+	}
+
+	resp, err := client.Get(entry.URL)
+	if err != nil {
+		return fmt.Errorf("mirror: downloading %s: %w", entry.URL, err)
+	}
+	defer resp.Body.Close()
+
+	var body bytes.Buffer
+	h := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(&body, h), resp.Body); err != nil {
+		return fmt.Errorf("mirror: downloading %s: %w", entry.URL, err)
+	}
+	if got := hex.EncodeToString(h.Sum(nil)); !strings.EqualFold(got, entry.SHA256) {
+		return fmt.Errorf("mirror: %s does not match recorded SHA256 %s, refusing to upload", entry.URL, entry.SHA256)
+	}
+
+	_, err = m.client.PutObject(ctx, m.bucket, key, bytes.NewReader(body.Bytes()), int64(body.Len()), minio.PutObjectOptions{
+		ContentType: "application/java-archive",
+	})
+	if err != nil {
+		return fmt.Errorf("mirror: uploading %s: %w", key, err)
+	}
+
+	entry.UpstreamURL = entry.URL
+	entry.URL = m.publicURL + "/" + key
+	return nil
+}
@@ -0,0 +1,102 @@
+package mirror
+
+import "testing"
+
+func TestConfigFromEnv(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		wantOK  bool
+		wantErr bool
+		want    Config
+	}{
+		{
+			name:   "endpoint unset means mirroring wasn't requested",
+			env:    map[string]string{},
+			wantOK: false,
+		},
+		{
+			name: "missing bucket is an error",
+			env: map[string]string{
+				"MIRROR_ENDPOINT":   "minio.internal:9000",
+				"MIRROR_PUBLIC_URL": "https://cdn.example.com",
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing public URL is an error",
+			env: map[string]string{
+				"MIRROR_ENDPOINT": "minio.internal:9000",
+				"MIRROR_BUCKET":   "artifacts",
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid MIRROR_USE_SSL is an error",
+			env: map[string]string{
+				"MIRROR_ENDPOINT":   "minio.internal:9000",
+				"MIRROR_BUCKET":     "artifacts",
+				"MIRROR_PUBLIC_URL": "https://cdn.example.com",
+				"MIRROR_USE_SSL":    "not-a-bool",
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid config with explicit SSL disabled",
+			env: map[string]string{
+				"MIRROR_ENDPOINT":   "minio.internal:9000",
+				"MIRROR_BUCKET":     "artifacts",
+				"MIRROR_PUBLIC_URL": "https://cdn.example.com/",
+				"MIRROR_ACCESS_KEY": "ak",
+				"MIRROR_SECRET_KEY": "sk",
+				"MIRROR_USE_SSL":    "false",
+			},
+			wantOK: true,
+			want: Config{
+				Endpoint:  "minio.internal:9000",
+				Bucket:    "artifacts",
+				AccessKey: "ak",
+				SecretKey: "sk",
+				PublicURL: "https://cdn.example.com",
+				UseSSL:    false,
+			},
+		},
+		{
+			name: "UseSSL defaults to true when unset",
+			env: map[string]string{
+				"MIRROR_ENDPOINT":   "minio.internal:9000",
+				"MIRROR_BUCKET":     "artifacts",
+				"MIRROR_PUBLIC_URL": "https://cdn.example.com",
+			},
+			wantOK: true,
+			want: Config{
+				Endpoint:  "minio.internal:9000",
+				Bucket:    "artifacts",
+				PublicURL: "https://cdn.example.com",
+				UseSSL:    true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, name := range []string{"MIRROR_ENDPOINT", "MIRROR_BUCKET", "MIRROR_ACCESS_KEY", "MIRROR_SECRET_KEY", "MIRROR_PUBLIC_URL", "MIRROR_USE_SSL"} {
+				t.Setenv(name, tt.env[name])
+			}
+
+			cfg, ok, err := ConfigFromEnv()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr = %v", err, tt.wantErr)
+			}
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if tt.wantErr || !tt.wantOK {
+				return
+			}
+			if cfg != tt.want {
+				t.Errorf("cfg = %+v, want %+v", cfg, tt.want)
+			}
+		})
+	}
+}
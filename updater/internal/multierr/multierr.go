@@ -0,0 +1,48 @@
+// Package multierr collects independent errors from concurrent work so
+// that one failure doesn't hide the others.
+package multierr
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Group accumulates errors from multiple goroutines. The zero value is
+// ready to use.
+type Group struct {
+	mu   sync.Mutex
+	errs []error
+}
+
+// Append records err, if non-nil. Safe for concurrent use.
+func (g *Group) Append(err error) {
+	if err == nil {
+		return
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.errs = append(g.errs, err)
+}
+
+// Len reports how many errors have been recorded so far.
+func (g *Group) Len() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return len(g.errs)
+}
+
+// ErrorOrNil returns nil if no errors were recorded, or an error
+// summarizing all of them otherwise.
+func (g *Group) ErrorOrNil() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if len(g.errs) == 0 {
+		return nil
+	}
+	msgs := make([]string, len(g.errs))
+	for i, err := range g.errs {
+		msgs[i] = err.Error()
+	}
+	return fmt.Errorf("%d error(s) occurred:\n\t%s", len(g.errs), strings.Join(msgs, "\n\t"))
+}
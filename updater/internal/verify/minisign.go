@@ -0,0 +1,29 @@
+package verify
+
+import (
+	"fmt"
+
+	"github.com/jedisct1/go-minisign"
+)
+
+// Minisign verifies a detached minisign signature over data using the
+// given base64-encoded public key. It returns an error if the signature
+// doesn't validate.
+func Minisign(pubkeyBase64 string, signature string, data []byte) error {
+	pub, err := minisign.NewPublicKey(pubkeyBase64)
+	if err != nil {
+		return fmt.Errorf("verify: parsing minisign public key: %w", err)
+	}
+	sig, err := minisign.DecodeSignature(signature)
+	if err != nil {
+		return fmt.Errorf("verify: parsing minisign signature: %w", err)
+	}
+	ok, err := pub.Verify(data, sig)
+	if err != nil {
+		return fmt.Errorf("verify: minisign verification: %w", err)
+	}
+	if !ok {
+		return fmt.Errorf("verify: minisign signature does not match")
+	}
+	return nil
+}
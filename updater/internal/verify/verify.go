@@ -0,0 +1,100 @@
+// Package verify downloads an artifact and checks its digest against
+// whatever hash an upstream API advertised for it, so a bad or tampered
+// mirror never gets written into serviceVersions.json as if it were good.
+package verify
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Expected is a digest an upstream API reported for an artifact, before
+// it has actually been downloaded. Algo is "sha256", "sha1", "md5", or
+// "" if the upstream gave us nothing to check against.
+type Expected struct {
+	Algo string
+	Hex  string
+}
+
+// Result is what downloading the artifact actually told us about it.
+type Result struct {
+	SHA256 string
+	Size   int64
+
+	// Body holds the downloaded bytes when Download was called with
+	// capture=true. It's nil otherwise, so callers that don't need the
+	// bytes (the common case) don't pay for buffering a multi-megabyte
+	// jar they're only hashing.
+	Body []byte
+}
+
+// Download streams url through client, always computing its SHA-256, and
+// additionally computes exp.Algo's digest (if different) to confirm it
+// matches exp.Hex. It returns an error without a usable Result if the
+// computed digest doesn't match. If capture is true, the downloaded
+// bytes are buffered into Result.Body, e.g. so a caller can also check a
+// detached signature over the same download without fetching it twice.
+func Download(ctx context.Context, client *http.Client, url string, exp Expected, capture bool) (Result, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return Result{}, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return Result{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return Result{}, fmt.Errorf("verify: fetching %s: unexpected status %s", url, resp.Status)
+	}
+
+	sha256h := sha256.New()
+	w := io.Writer(sha256h)
+
+	var checkHash hash.Hash
+	switch exp.Algo {
+	case "", "sha256":
+		checkHash = sha256h
+	case "sha1":
+		checkHash = sha1.New()
+		w = io.MultiWriter(sha256h, checkHash)
+	case "md5":
+		checkHash = md5.New()
+		w = io.MultiWriter(sha256h, checkHash)
+	default:
+		return Result{}, fmt.Errorf("verify: unsupported digest algorithm %q", exp.Algo)
+	}
+
+	var body *bytes.Buffer
+	if capture {
+		body = &bytes.Buffer{}
+		w = io.MultiWriter(w, body)
+	}
+
+	n, err := io.Copy(w, resp.Body)
+	if err != nil {
+		return Result{}, err
+	}
+	result := Result{SHA256: hex.EncodeToString(sha256h.Sum(nil)), Size: n}
+	if body != nil {
+		result.Body = body.Bytes()
+	}
+
+	if exp.Algo != "" && exp.Hex != "" {
+		got := hex.EncodeToString(checkHash.Sum(nil))
+		if !strings.EqualFold(got, exp.Hex) {
+			return Result{}, fmt.Errorf("checksum mismatch for %s: upstream %s=%s, computed %s", url, exp.Algo, exp.Hex, got)
+		}
+	}
+	return result, nil
+}
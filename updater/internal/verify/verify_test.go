@@ -0,0 +1,100 @@
+package verify
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDownload_ChecksumMatch(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("hello world"))
+	}))
+	defer srv.Close()
+
+	// sha256("hello world")
+	const wantSHA256 = "b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9"
+
+	result, err := Download(context.Background(), srv.Client(), srv.URL, Expected{Algo: "sha256", Hex: wantSHA256}, false)
+	if err != nil {
+		t.Fatalf("Download returned error: %v", err)
+	}
+	if result.SHA256 != wantSHA256 {
+		t.Errorf("SHA256 = %q, want %q", result.SHA256, wantSHA256)
+	}
+	if result.Size != int64(len("hello world")) {
+		t.Errorf("Size = %d, want %d", result.Size, len("hello world"))
+	}
+	if result.Body != nil {
+		t.Errorf("Body = %v, want nil when capture is false", result.Body)
+	}
+}
+
+func TestDownload_ChecksumMismatch(t *testing.T) {
+	cases := []struct {
+		name string
+		algo string
+		hex  string
+	}{
+		{"sha256", "sha256", "0000000000000000000000000000000000000000000000000000000000000000"},
+		{"sha1", "sha1", "0000000000000000000000000000000000000000000000"},
+		{"md5", "md5", "000000000000000000000000000000"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte("hello world"))
+			}))
+			defer srv.Close()
+
+			_, err := Download(context.Background(), srv.Client(), srv.URL, Expected{Algo: c.algo, Hex: c.hex}, false)
+			if err == nil {
+				t.Fatalf("Download with wrong %s expected a checksum mismatch error, got none", c.algo)
+			}
+		})
+	}
+}
+
+func TestDownload_NonOKStatus(t *testing.T) {
+	cases := []int{http.StatusNotFound, http.StatusTooManyRequests, http.StatusInternalServerError}
+	for _, status := range cases {
+		t.Run(http.StatusText(status), func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(status)
+				w.Write([]byte("error page"))
+			}))
+			defer srv.Close()
+
+			if _, err := Download(context.Background(), srv.Client(), srv.URL, Expected{}, false); err == nil {
+				t.Fatalf("Download with status %d expected an error, got none (error page would be hashed as if it were the artifact)", status)
+			}
+		})
+	}
+}
+
+func TestDownload_UnsupportedAlgo(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("hello world"))
+	}))
+	defer srv.Close()
+
+	if _, err := Download(context.Background(), srv.Client(), srv.URL, Expected{Algo: "sha512", Hex: "anything"}, false); err == nil {
+		t.Fatal("Download with unsupported algo expected an error, got none")
+	}
+}
+
+func TestDownload_Capture(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("hello world"))
+	}))
+	defer srv.Close()
+
+	result, err := Download(context.Background(), srv.Client(), srv.URL, Expected{}, true)
+	if err != nil {
+		t.Fatalf("Download returned error: %v", err)
+	}
+	if string(result.Body) != "hello world" {
+		t.Errorf("Body = %q, want %q", result.Body, "hello world")
+	}
+}
@@ -1,290 +1,223 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
 	"net/http"
 	"os"
-	"sort"
 	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/ezTxmMC/rustnesscloud-manifest/updater/internal/httpcache"
+	"github.com/ezTxmMC/rustnesscloud-manifest/updater/internal/mirror"
+	"github.com/ezTxmMC/rustnesscloud-manifest/updater/internal/multierr"
+	"github.com/ezTxmMC/rustnesscloud-manifest/updater/providers"
 )
 
 // Data structures matching your serviceVersions.json
-type Entry struct {
-	URL      string `json:"url"`
-	Snapshot *bool  `json:"snapshot,omitempty"`
-}
-type VersionMap map[string]Entry
-
 type ServiceVersions struct {
 	Proxy struct {
-		Velocity   VersionMap `json:"VELOCITY"`
-		BungeeCord VersionMap `json:"BUNGEECORD"`
-		Waterfall  VersionMap `json:"WATERFALL"`
+		Velocity   providers.VersionMap `json:"VELOCITY"`
+		BungeeCord providers.VersionMap `json:"BUNGEECORD"`
+		Waterfall  providers.VersionMap `json:"WATERFALL"`
 	} `json:"PROXY"`
 	Server struct {
-		Paper      VersionMap `json:"PAPER"`
-		Pufferfish VersionMap `json:"PUFFERFISH"`
-		Purpur     VersionMap `json:"PURPUR"`
-		Folia      VersionMap `json:"FOLIA"`
-		Vanilla    VersionMap `json:"VANILLA"`
+		Paper      providers.VersionMap `json:"PAPER"`
+		Pufferfish providers.VersionMap `json:"PUFFERFISH"`
+		Purpur     providers.VersionMap `json:"PURPUR"`
+		Folia      providers.VersionMap `json:"FOLIA"`
+		Vanilla    providers.VersionMap `json:"VANILLA"`
+		Fabric     providers.VersionMap `json:"FABRIC"`
+		NeoForge   providers.VersionMap `json:"NEOFORGE"`
+		Sponge     providers.VersionMap `json:"SPONGE"`
+		Mohist     providers.VersionMap `json:"MOHIST"`
 	} `json:"SERVER"`
 }
 
-// Get all available versions from API for a project (flattened)
-func getPaperLikeVersions(project string) ([]string, error) {
-	api := fmt.Sprintf("https://fill.papermc.io/v3/projects/%s", project)
-	var result struct {
-		Versions map[string][]string `json:"versions"`
-	}
-	resp, err := http.Get(api)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, err
-	}
-	var versions []string
-	for _, sub := range result.Versions {
-		versions = append(versions, sub...)
-	}
-	// Sort descending (latest first)
-	sort.Slice(versions, func(i, j int) bool {
-		return versions[i] > versions[j]
-	})
-	return versions, nil
-}
-
-// Purpur: Get all available versions from API
-func getPurpurVersions() ([]string, error) {
-	api := "https://api.purpurmc.org/v2/purpur"
-	var result struct {
-		Versions []string `json:"versions"`
-	}
-	resp, err := http.Get(api)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, err
+// versionMapFor returns a pointer to the slot in data that p's entries
+// belong in, or nil if ServiceVersions has no slot for it.
+func versionMapFor(data *ServiceVersions, p providers.Provider) *providers.VersionMap {
+	switch p.Kind() {
+	case providers.KindProxy:
+		switch p.Name() {
+		case "VELOCITY":
+			return &data.Proxy.Velocity
+		case "BUNGEECORD":
+			return &data.Proxy.BungeeCord
+		case "WATERFALL":
+			return &data.Proxy.Waterfall
+		}
+	case providers.KindServer:
+		switch p.Name() {
+		case "PAPER":
+			return &data.Server.Paper
+		case "PUFFERFISH":
+			return &data.Server.Pufferfish
+		case "PURPUR":
+			return &data.Server.Purpur
+		case "FOLIA":
+			return &data.Server.Folia
+		case "VANILLA":
+			return &data.Server.Vanilla
+		case "FABRIC":
+			return &data.Server.Fabric
+		case "NEOFORGE":
+			return &data.Server.NeoForge
+		case "SPONGE":
+			return &data.Server.Sponge
+		case "MOHIST":
+			return &data.Server.Mohist
+		}
 	}
-	// Sort descending (latest first)
-	sort.Slice(result.Versions, func(i, j int) bool {
-		return result.Versions[i] > result.Versions[j]
-	})
-	return result.Versions, nil
+	return nil
 }
 
-// Get latest build download URL for a project/version (new API)
-func getProjectLatestDownloadURL(project, version string) (string, error) {
-	api := fmt.Sprintf("https://fill.papermc.io/v3/projects/%s/versions/%s/builds/latest", project, version)
-	var result struct {
-		Downloads map[string]struct {
-			URL string `json:"url"`
-		} `json:"downloads"`
-	}
-	resp, err := http.Get(api)
-	if err != nil {
-		return "", err
-	}
-	defer resp.Body.Close()
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return "", err
-	}
-	download, ok := result.Downloads["server:default"]
-	if !ok || download.URL == "" {
-		return "", fmt.Errorf("no server:default download found for %s %s", project, version)
-	}
-	return download.URL, nil
+// For pretty JSON keys (replace . with _)
+func versionToKey(version string) string {
+	return strings.ReplaceAll(version, ".", "_")
 }
 
-// Universal updater for a PaperMC project
-func updatePaperMCProject(project string, versionMap VersionMap) {
-	fmt.Printf("== Checking %s ==\n", strings.Title(project))
-	versions, err := getPaperLikeVersions(project)
-	if err != nil {
-		fmt.Printf("%s: Error loading versions: %v\n", strings.Title(project), err)
-		return
-	}
-	for _, v := range versions {
-		key := versionToKey(v)
-		url, err := getProjectLatestDownloadURL(project, v)
-		if err != nil {
-			fmt.Printf("%s %s: Error: %v\n", strings.Title(project), v, err)
-			continue
-		}
-		if entry, ok := versionMap[key]; !ok || entry.URL != url {
-			if ok {
-				fmt.Printf("%s %s: Updated download URL.\n", strings.Title(project), v)
-			} else {
-				fmt.Printf("%s %s: Added missing version.\n", strings.Title(project), v)
-			}
-			versionMap[key] = Entry{URL: url}
-		} else {
-			fmt.Printf("%s %s: Already up to date.\n", strings.Title(project), v)
+// parseChannels turns a comma-separated -channels value into
+// ChannelOptions, rejecting anything it doesn't recognize.
+func parseChannels(raw string) (providers.ChannelOptions, error) {
+	var opts providers.ChannelOptions
+	for _, name := range strings.Split(raw, ",") {
+		switch strings.TrimSpace(name) {
+		case "release":
+			opts.Release = true
+		case "snapshot":
+			opts.Snapshot = true
+		case "experimental":
+			opts.Experimental = true
+		default:
+			return providers.ChannelOptions{}, fmt.Errorf("unknown channel %q (want release, snapshot, or experimental)", name)
 		}
 	}
+	return opts, nil
 }
 
-// Purpur: Get latest build download URL for a version
-func getPurpurLatestBuildURL(version string) (string, error) {
-	api := fmt.Sprintf("https://api.purpurmc.org/v2/purpur/%s", version)
-	var result struct {
-		Builds struct {
-			Latest string `json:"latest"`
-		} `json:"builds"`
-	}
-	resp, err := http.Get(api)
-	if err != nil {
-		return "", err
-	}
-	defer resp.Body.Close()
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return "", err
-	}
-	if result.Builds.Latest == "" {
-		return "", fmt.Errorf("no latest build found for version %s", version)
-	}
-	return fmt.Sprintf("https://api.purpurmc.org/v2/purpur/%s/%s/download", version, result.Builds.Latest), nil
+// providerStats summarizes one updateProvider run for the closing
+// per-project log line.
+type providerStats struct {
+	label    string
+	versions int
+	changed  int
 }
 
-// Universal updater for Purpur
-func updatePurpurProject(versionMap VersionMap) {
-	fmt.Println("== Checking Purpur ==")
-	versions, err := getPurpurVersions()
+// updateProvider refreshes every version a provider knows about into
+// versionMap. Per-version resolves run concurrently, bounded by
+// concurrency; every failure is recorded rather than aborting the run.
+// rawClient is used for mirroring, which downloads whole artifacts and
+// must not go through client's metadata cache.
+func updateProvider(ctx context.Context, p providers.Provider, versionMap providers.VersionMap, concurrency int, rawClient *http.Client, mir *mirror.Mirror) (providerStats, error) {
+	label := strings.Title(strings.ToLower(p.Name()))
+	fmt.Printf("== Checking %s ==\n", label)
+	versions, err := p.Versions(ctx)
 	if err != nil {
-		fmt.Printf("Purpur: Error loading versions: %v\n", err)
-		return
+		return providerStats{}, fmt.Errorf("%s: loading versions: %w", label, err)
 	}
+
+	var mu sync.Mutex
+	var errs multierr.Group
+	stats := providerStats{label: label, versions: len(versions)}
+	g, _ := errgroup.WithContext(ctx)
+	g.SetLimit(concurrency)
 	for _, v := range versions {
-		key := versionToKey(v)
-		url, err := getPurpurLatestBuildURL(v)
-		if err != nil {
-			fmt.Printf("Purpur %s: Error: %v\n", v, err)
-			continue
-		}
-		if entry, ok := versionMap[key]; !ok || entry.URL != url {
-			if ok {
-				fmt.Printf("Purpur %s: Updated download URL.\n", v)
+		v := v
+		g.Go(func() error {
+			entry, err := p.Resolve(ctx, v)
+			if errors.Is(err, providers.ErrSkip) {
+				fmt.Printf("%s %s: Skipped (channel filtered).\n", label, v)
+				return nil
+			}
+			if err != nil {
+				errs.Append(fmt.Errorf("%s %s: %w", label, v, err))
+				return nil
+			}
+			// Snapshots get their own key namespace so a non-release
+			// build never shadows the release entry for the same
+			// version number.
+			key := versionToKey(v)
+			if entry.Snapshot != nil && *entry.Snapshot {
+				key = "snap_" + key
+			}
+
+			if mir != nil {
+				if entry.SHA256 == "" {
+					fmt.Printf("%s %s: mirror skipped (no SHA256; run with -verify)\n", label, v)
+				} else if err := mir.Put(ctx, rawClient, strings.ToLower(p.Name()), v, &entry); err != nil {
+					errs.Append(fmt.Errorf("%s %s: mirroring: %w", label, v, err))
+				}
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if existing, ok := versionMap[key]; !ok || existing.URL != entry.URL {
+				if ok {
+					fmt.Printf("%s %s: Updated download URL.\n", label, v)
+				} else {
+					fmt.Printf("%s %s: Added missing version.\n", label, v)
+				}
+				versionMap[key] = entry
+				stats.changed++
 			} else {
-				fmt.Printf("Purpur %s: Added missing version.\n", v)
+				fmt.Printf("%s %s: Already up to date.\n", label, v)
 			}
-			versionMap[key] = Entry{URL: url}
-		} else {
-			fmt.Printf("Purpur %s: Already up to date.\n", v)
-		}
+			return nil
+		})
 	}
+	_ = g.Wait()
+	return stats, errs.ErrorOrNil()
 }
 
-// Liefert alle Vanilla-Vollversionen (neueste zuerst)
-func getVanillaReleaseVersions() ([]string, error) {
-	api := "https://piston-meta.mojang.com/mc/game/version_manifest_v2.json"
-	var result struct {
-		Versions []struct {
-			ID   string `json:"id"`
-			Type string `json:"type"`
-		} `json:"versions"`
-	}
-	resp, err := http.Get(api)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, err
-	}
-	var releases []string
-	for _, v := range result.Versions {
-		if v.Type == "release" {
-			releases = append(releases, v.ID)
-		}
-	}
-	return releases, nil
-}
+func main() {
+	concurrency := flag.Int("concurrency", 8, "max concurrent per-version requests per project")
+	doVerify := flag.Bool("verify", false, "download every resolved artifact and confirm its checksum before recording it")
+	minisignPubkey := flag.String("minisign-pubkey", "", "base64 minisign public key to verify detached signatures against, when a project publishes one")
+	channelsFlag := flag.String("channels", "release", "comma-separated channels to include: release, snapshot, experimental")
+	noCache := flag.Bool("no-cache", false, "bypass the on-disk HTTP cache entirely")
+	maxAge := flag.Duration("max-age", 0, "skip conditional GETs and serve cached responses younger than this outright, e.g. 10m")
+	doMirror := flag.Bool("mirror", false, "upload new/changed artifacts to the S3-compatible bucket configured via MIRROR_* env vars and rewrite their URLs")
+	flag.Parse()
 
-// Holt die Download-URL für die Server-JAR einer bestimmten Vanilla-Version
-func getVanillaDownloadURL(version string) (string, error) {
-	manifestURL := "https://piston-meta.mojang.com/mc/game/version_manifest_v2.json"
-	var manifest struct {
-		Versions []struct {
-			ID  string `json:"id"`
-			URL string `json:"url"`
-		} `json:"versions"`
-	}
-	resp, err := http.Get(manifestURL)
-	if err != nil {
-		return "", err
-	}
-	defer resp.Body.Close()
-	if err := json.NewDecoder(resp.Body).Decode(&manifest); err != nil {
-		return "", err
-	}
-	var versionURL string
-	for _, v := range manifest.Versions {
-		if v.ID == version {
-			versionURL = v.URL
-			break
-		}
-	}
-	if versionURL == "" {
-		return "", fmt.Errorf("version %s not found in manifest", version)
+	vopts := providers.VerifyOptions{Enabled: *doVerify, MinisignPubkey: *minisignPubkey}
+	if *minisignPubkey != "" && !*doVerify {
+		fmt.Println("warning: -minisign-pubkey has no effect without -verify")
 	}
-	var versionManifest struct {
-		Downloads struct {
-			Server struct {
-				URL string `json:"url"`
-			} `json:"server"`
-		} `json:"downloads"`
-	}
-	resp2, err := http.Get(versionURL)
-	if err != nil {
-		return "", err
-	}
-	defer resp2.Body.Close()
-	if err := json.NewDecoder(resp2.Body).Decode(&versionManifest); err != nil {
-		return "", err
-	}
-	if versionManifest.Downloads.Server.URL == "" {
-		return "", fmt.Errorf("no server download found for version %s", version)
-	}
-	return versionManifest.Downloads.Server.URL, nil
-}
 
-func updateVanillaReleaseProject(versionMap VersionMap) {
-	fmt.Println("== Checking Vanilla (Releases only) ==")
-	versions, err := getVanillaReleaseVersions()
+	channels, err := parseChannels(*channelsFlag)
 	if err != nil {
-		fmt.Printf("Vanilla: Error loading versions: %v\n", err)
-		return
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
 	}
-	for _, v := range versions {
-		key := versionToKey(v)
-		url, err := getVanillaDownloadURL(v)
+
+	var mir *mirror.Mirror
+	if *doMirror {
+		cfg, ok, err := mirror.ConfigFromEnv()
 		if err != nil {
-			fmt.Printf("Vanilla %s: Error: %v\n", v, err)
-			continue
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
 		}
-		if entry, ok := versionMap[key]; !ok || entry.URL != url {
-			if ok {
-				fmt.Printf("Vanilla %s: Updated download URL.\n", v)
-			} else {
-				fmt.Printf("Vanilla %s: Added missing version.\n", v)
-			}
-			versionMap[key] = Entry{URL: url}
-		} else {
-			fmt.Printf("Vanilla %s: Already up to date.\n", v)
+		if !ok {
+			fmt.Fprintln(os.Stderr, "mirror: -mirror was given but MIRROR_ENDPOINT is not set")
+			os.Exit(1)
+		}
+		mir, err = mirror.New(cfg)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		if !*doVerify {
+			fmt.Println("warning: -mirror without -verify will skip every artifact whose upstream publishes no SHA256 to key the upload by")
 		}
 	}
-}
-
-// For pretty JSON keys (replace . with _)
-func versionToKey(version string) string {
-	return strings.ReplaceAll(version, ".", "_")
-}
 
-func main() {
 	// Load file
 	file, err := os.Open("serviceVersions.json")
 	if err != nil {
@@ -297,15 +230,46 @@ func main() {
 		panic(err)
 	}
 
-	// Update all projects
-	updatePaperMCProject("paper", data.Server.Paper)
-	updatePaperMCProject("folia", data.Server.Folia)
-	updatePaperMCProject("velocity", data.Proxy.Velocity)
-	updatePaperMCProject("waterfall", data.Proxy.Waterfall)
-	updatePurpurProject(data.Server.Purpur)
-	updateVanillaReleaseProject(data.Server.Vanilla)
+	cachePath, err := httpcache.DefaultPath()
+	if err != nil {
+		panic(err)
+	}
+	cache, err := httpcache.Load(cachePath, *noCache, *maxAge)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: could not load HTTP cache, continuing without it: %v\n", err)
+		cache, _ = httpcache.Load(cachePath, true, *maxAge)
+	}
+
+	ctx := context.Background()
+	// client serves cacheable metadata requests (version lists, build
+	// lookups). rawClient is for full-artifact downloads in -verify and
+	// -mirror, which must never be buffered into the metadata cache.
+	client := &http.Client{Timeout: 30 * time.Second, Transport: cache.Transport(http.DefaultTransport)}
+	rawClient := &http.Client{Timeout: 10 * time.Minute}
+
+	var errs multierr.Group
+	for _, p := range providers.New(client, rawClient, vopts, channels) {
+		versionMap := versionMapFor(&data, p)
+		if versionMap == nil {
+			errs.Append(fmt.Errorf("%s: no slot in serviceVersions.json for kind %s", p.Name(), p.Kind()))
+			continue
+		}
+		if *versionMap == nil {
+			*versionMap = make(providers.VersionMap)
+		}
+		cache.ResetStats()
+		stats, err := updateProvider(ctx, p, *versionMap, *concurrency, rawClient, mir)
+		served, refreshed := cache.Stats()
+		fmt.Printf("%s: %d versions, %d changed, %d served from cache, %d refreshed\n", stats.label, stats.versions, stats.changed, served, refreshed)
+		errs.Append(err)
+	}
+
+	if err := cache.Save(); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: could not save HTTP cache: %v\n", err)
+	}
 
-	// Write file back
+	// Write file back. encoding/json sorts map keys when encoding, so the
+	// output is deterministic regardless of fetch order.
 	out, err := os.Create("serviceVersions.json")
 	if err != nil {
 		panic(err)
@@ -317,4 +281,9 @@ func main() {
 		panic(err)
 	}
 	fmt.Println("serviceVersions.json has been updated!")
+
+	if err := errs.ErrorOrNil(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
 }
@@ -0,0 +1,93 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/ezTxmMC/rustnesscloud-manifest/updater/internal/mcver"
+	"github.com/ezTxmMC/rustnesscloud-manifest/updater/internal/verify"
+)
+
+func init() {
+	Register("FABRIC", func(client, rawClient *http.Client, vopts VerifyOptions, _ ChannelOptions) Provider {
+		return &fabricProvider{client: client, rawClient: rawClient, verify: vopts}
+	})
+}
+
+// fabricProvider resolves Fabric server launcher jars from meta.fabricmc.net.
+// Unlike Paper/Purpur, Fabric has no single "latest build" per game
+// version: the server jar is assembled from a game version plus the
+// newest loader and installer versions, which are fetched independently.
+type fabricProvider struct {
+	client    *http.Client
+	rawClient *http.Client
+	verify    VerifyOptions
+}
+
+func (p *fabricProvider) Name() string { return "FABRIC" }
+func (p *fabricProvider) Kind() Kind   { return KindServer }
+
+func (p *fabricProvider) Versions(ctx context.Context) ([]string, error) {
+	var result []struct {
+		Version string `json:"version"`
+		Stable  bool   `json:"stable"`
+	}
+	resp, err := httpGet(ctx, p.client, "https://meta.fabricmc.net/v2/versions/game")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	var versions []string
+	for _, v := range result {
+		if v.Stable {
+			versions = append(versions, v.Version)
+		}
+	}
+	mcver.SortDescending(versions)
+	return versions, nil
+}
+
+func (p *fabricProvider) latestOf(ctx context.Context, path string) (string, error) {
+	var result []struct {
+		Version string `json:"version"`
+	}
+	resp, err := httpGet(ctx, p.client, "https://meta.fabricmc.net/v2/versions/"+path)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", err
+	}
+	if len(result) == 0 {
+		return "", fmt.Errorf("no %s versions published", path)
+	}
+	return result[0].Version, nil // meta.fabricmc.net lists newest first
+}
+
+func (p *fabricProvider) Resolve(ctx context.Context, version string) (Entry, error) {
+	loader, err := p.latestOf(ctx, fmt.Sprintf("loader/%s", version))
+	if err != nil {
+		return Entry{}, fmt.Errorf("fabric loader for %s: %w", version, err)
+	}
+	installer, err := p.latestOf(ctx, "installer")
+	if err != nil {
+		return Entry{}, fmt.Errorf("fabric installer: %w", err)
+	}
+	url := fmt.Sprintf("https://meta.fabricmc.net/v2/versions/loader/%s/%s/%s/server/jar", version, loader, installer)
+
+	entry := Entry{URL: url}
+	if p.verify.Enabled {
+		result, err := verify.Download(ctx, p.rawClient, url, verify.Expected{}, false)
+		if err != nil {
+			return Entry{}, err
+		}
+		entry.SHA256, entry.Size = result.SHA256, result.Size
+	}
+	return entry, nil
+}
@@ -0,0 +1,73 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/ezTxmMC/rustnesscloud-manifest/updater/internal/mcver"
+	"github.com/ezTxmMC/rustnesscloud-manifest/updater/internal/verify"
+)
+
+func init() {
+	Register("MOHIST", func(client, rawClient *http.Client, vopts VerifyOptions, _ ChannelOptions) Provider {
+		return &mohistProvider{client: client, rawClient: rawClient, verify: vopts}
+	})
+}
+
+const mohistProjectURL = "https://mohistmc.com/api/v2/projects/mohist"
+
+type mohistProvider struct {
+	client    *http.Client
+	rawClient *http.Client
+	verify    VerifyOptions
+}
+
+func (p *mohistProvider) Name() string { return "MOHIST" }
+func (p *mohistProvider) Kind() Kind   { return KindServer }
+
+func (p *mohistProvider) Versions(ctx context.Context) ([]string, error) {
+	var result struct {
+		Versions []string `json:"versions"`
+	}
+	resp, err := httpGet(ctx, p.client, mohistProjectURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	mcver.SortDescending(result.Versions)
+	return result.Versions, nil
+}
+
+func (p *mohistProvider) Resolve(ctx context.Context, version string) (Entry, error) {
+	api := fmt.Sprintf("%s/%s/builds/latest", mohistProjectURL, version)
+	var result struct {
+		URL string `json:"url"`
+		Md5 string `json:"fileMd5"`
+	}
+	resp, err := httpGet(ctx, p.client, api)
+	if err != nil {
+		return Entry{}, err
+	}
+	defer resp.Body.Close()
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return Entry{}, err
+	}
+	if result.URL == "" {
+		return Entry{}, fmt.Errorf("no latest build found for Mohist %s", version)
+	}
+
+	entry := Entry{URL: result.URL}
+	if p.verify.Enabled {
+		verified, err := verify.Download(ctx, p.rawClient, result.URL, verify.Expected{Algo: "md5", Hex: result.Md5}, false)
+		if err != nil {
+			return Entry{}, err
+		}
+		entry.SHA256, entry.Size = verified.SHA256, verified.Size
+	}
+	return entry, nil
+}
@@ -0,0 +1,93 @@
+package providers
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/ezTxmMC/rustnesscloud-manifest/updater/internal/mcver"
+	"github.com/ezTxmMC/rustnesscloud-manifest/updater/internal/verify"
+)
+
+func init() {
+	Register("NEOFORGE", func(client, rawClient *http.Client, vopts VerifyOptions, _ ChannelOptions) Provider {
+		return &neoForgeProvider{client: client, rawClient: rawClient, verify: vopts}
+	})
+}
+
+const neoForgeMetadataURL = "https://maven.neoforged.net/releases/net/neoforged/neoforge/maven-metadata.xml"
+
+// neoForgeProvider resolves NeoForge installer jars from the Maven
+// repository NeoForge publishes releases to. NeoForge versions its
+// releases independently of the Minecraft version they target (e.g.
+// "21.1.84" for Minecraft 1.21.1), so Versions returns NeoForge's own
+// version strings rather than Minecraft ones.
+type neoForgeProvider struct {
+	client    *http.Client
+	rawClient *http.Client
+	verify    VerifyOptions
+}
+
+func (p *neoForgeProvider) Name() string { return "NEOFORGE" }
+func (p *neoForgeProvider) Kind() Kind   { return KindServer }
+
+func (p *neoForgeProvider) Versions(ctx context.Context) ([]string, error) {
+	resp, err := httpGet(ctx, p.client, neoForgeMetadataURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var metadata struct {
+		Versioning struct {
+			Versions struct {
+				Version []string `xml:"version"`
+			} `xml:"versions"`
+		} `xml:"versioning"`
+	}
+	if err := xml.NewDecoder(resp.Body).Decode(&metadata); err != nil {
+		return nil, err
+	}
+	versions := metadata.Versioning.Versions.Version
+	mcver.SortDescending(versions)
+	return versions, nil
+}
+
+// fetchSHA1 fetches the ".sha1" sidecar Maven publishes next to every
+// artifact it hosts.
+func (p *neoForgeProvider) fetchSHA1(ctx context.Context, url string) (string, error) {
+	resp, err := httpGet(ctx, p.client, url+".sha1")
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("fetching %s.sha1: unexpected status %s", url, resp.Status)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(body)), nil
+}
+
+func (p *neoForgeProvider) Resolve(ctx context.Context, version string) (Entry, error) {
+	url := fmt.Sprintf("https://maven.neoforged.net/releases/net/neoforged/neoforge/%s/neoforge-%s-installer.jar", version, version)
+
+	entry := Entry{URL: url}
+	if p.verify.Enabled {
+		sha1hex, err := p.fetchSHA1(ctx, url)
+		if err != nil {
+			return Entry{}, fmt.Errorf("fetching sha1: %w", err)
+		}
+		result, err := verify.Download(ctx, p.rawClient, url, verify.Expected{Algo: "sha1", Hex: sha1hex}, false)
+		if err != nil {
+			return Entry{}, err
+		}
+		entry.SHA256, entry.Size = result.SHA256, result.Size
+	}
+	return entry, nil
+}
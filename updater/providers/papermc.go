@@ -0,0 +1,107 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/ezTxmMC/rustnesscloud-manifest/updater/internal/mcver"
+	"github.com/ezTxmMC/rustnesscloud-manifest/updater/internal/verify"
+)
+
+func init() {
+	Register("PAPER", newPaperMC("PAPER", "paper", KindServer))
+	Register("FOLIA", newPaperMC("FOLIA", "folia", KindServer))
+	Register("VELOCITY", newPaperMC("VELOCITY", "velocity", KindProxy))
+	Register("WATERFALL", newPaperMC("WATERFALL", "waterfall", KindProxy))
+}
+
+// paperMCProvider covers every distribution served by PaperMC's v3 API:
+// Paper and Folia (servers), Velocity and Waterfall (proxies).
+type paperMCProvider struct {
+	name      string
+	project   string
+	kind      Kind
+	client    *http.Client
+	rawClient *http.Client
+	verify    VerifyOptions
+	channels  ChannelOptions
+}
+
+func newPaperMC(name, project string, kind Kind) Constructor {
+	return func(client, rawClient *http.Client, vopts VerifyOptions, channels ChannelOptions) Provider {
+		return &paperMCProvider{name: name, project: project, kind: kind, client: client, rawClient: rawClient, verify: vopts, channels: channels}
+	}
+}
+
+func (p *paperMCProvider) Name() string { return p.name }
+func (p *paperMCProvider) Kind() Kind   { return p.kind }
+
+func (p *paperMCProvider) Versions(ctx context.Context) ([]string, error) {
+	api := fmt.Sprintf("https://fill.papermc.io/v3/projects/%s", p.project)
+	var result struct {
+		Versions map[string][]string `json:"versions"`
+	}
+	resp, err := httpGet(ctx, p.client, api)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	var versions []string
+	for _, sub := range result.Versions {
+		versions = append(versions, sub...)
+	}
+	mcver.SortDescending(versions)
+	return versions, nil
+}
+
+func (p *paperMCProvider) Resolve(ctx context.Context, version string) (Entry, error) {
+	api := fmt.Sprintf("https://fill.papermc.io/v3/projects/%s/versions/%s/builds/latest", p.project, version)
+	var result struct {
+		Channel   string `json:"channel"`
+		Downloads map[string]struct {
+			URL       string `json:"url"`
+			Size      int64  `json:"size"`
+			Checksums struct {
+				SHA256 string `json:"sha256"`
+			} `json:"checksums"`
+		} `json:"downloads"`
+	}
+	resp, err := httpGet(ctx, p.client, api)
+	if err != nil {
+		return Entry{}, err
+	}
+	defer resp.Body.Close()
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return Entry{}, err
+	}
+	download, ok := result.Downloads["server:default"]
+	if !ok || download.URL == "" {
+		return Entry{}, fmt.Errorf("no server:default download found for %s %s", p.project, version)
+	}
+
+	// PaperMC's v3 API has no separate snapshot tier: any non-STABLE
+	// channel is its one pre-release bucket, so either -channels flag
+	// that asks for pre-releases should surface it.
+	stable := result.Channel == "" || result.Channel == "STABLE"
+	if !stable && !p.channels.Snapshot && !p.channels.Experimental {
+		return Entry{}, ErrSkip
+	}
+
+	entry := Entry{URL: download.URL, SHA256: download.Checksums.SHA256, Size: download.Size}
+	if !stable {
+		entry.Snapshot = boolPtr(true)
+	}
+	if p.verify.Enabled {
+		result, err := verify.Download(ctx, p.rawClient, download.URL, verify.Expected{Algo: "sha256", Hex: download.Checksums.SHA256}, false)
+		if err != nil {
+			return Entry{}, err
+		}
+		entry.SHA256, entry.Size = result.SHA256, result.Size
+	}
+	return entry, nil
+}
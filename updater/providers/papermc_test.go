@@ -0,0 +1,60 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func paperBuildFixture(channel string) string {
+	return `{
+  "channel": "` + channel + `",
+  "downloads": {
+    "server:default": {
+      "url": "https://example.invalid/paper.jar",
+      "size": 123,
+      "checksums": {"sha256": "abc"}
+    }
+  }
+}`
+}
+
+func TestPaperMCProvider_Resolve_ChannelGating(t *testing.T) {
+	tests := []struct {
+		name     string
+		channel  string
+		channels ChannelOptions
+		wantSkip bool
+	}{
+		{"stable always allowed", "STABLE", ChannelOptions{Release: true}, false},
+		{"non-stable skipped with only release", "BETA", ChannelOptions{Release: true}, true},
+		{"non-stable allowed under snapshot", "BETA", ChannelOptions{Snapshot: true}, false},
+		{"non-stable allowed under experimental", "BETA", ChannelOptions{Experimental: true}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(paperBuildFixture(tt.channel)))
+			}))
+			defer srv.Close()
+
+			p := &paperMCProvider{name: "PAPER", project: "paper", kind: KindServer, client: redirectingClient(srv), channels: tt.channels}
+			entry, err := p.Resolve(context.Background(), "1.21")
+			if tt.wantSkip {
+				if !errors.Is(err, ErrSkip) {
+					t.Fatalf("err = %v, want ErrSkip", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Resolve returned error: %v", err)
+			}
+			if entry.URL != "https://example.invalid/paper.jar" {
+				t.Errorf("URL = %q, want paper.jar download", entry.URL)
+			}
+		})
+	}
+}
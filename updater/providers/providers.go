@@ -0,0 +1,131 @@
+// Package providers defines the pluggable Provider interface that each
+// supported server/proxy distribution implements, plus the registry that
+// lets main discover all of them without knowing their concrete types.
+//
+// A new distribution is added by dropping a file into this package that
+// implements Provider and calls Register from an init() function.
+package providers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+)
+
+// Kind says which top-level bucket of serviceVersions.json a provider's
+// entries belong under.
+type Kind string
+
+const (
+	KindProxy  Kind = "PROXY"
+	KindServer Kind = "SERVER"
+)
+
+// Entry is a single resolved download, matching serviceVersions.json.
+type Entry struct {
+	URL       string `json:"url"`
+	SHA256    string `json:"sha256,omitempty"`
+	Size      int64  `json:"size,omitempty"`
+	Signature string `json:"signature,omitempty"`
+	Snapshot  *bool  `json:"snapshot,omitempty"`
+
+	// UpstreamURL is the original, unmirrored download location. It's
+	// only set once URL has been rewritten to point at a mirror.
+	UpstreamURL string `json:"upstreamUrl,omitempty"`
+}
+
+// VersionMap maps a versionToKey-encoded version to its Entry.
+type VersionMap map[string]Entry
+
+// VerifyOptions controls the optional download verification every
+// provider performs in Resolve.
+type VerifyOptions struct {
+	Enabled        bool
+	MinisignPubkey string
+}
+
+// ChannelOptions selects which release channels a provider should
+// surface. A pre-release version or build outside every enabled channel
+// is left out of Versions/Resolve entirely rather than written with
+// Snapshot=false. Only Vanilla also drops releases when Release is off;
+// PaperMC stable builds and release-only providers are always included.
+type ChannelOptions struct {
+	Release      bool
+	Snapshot     bool
+	Experimental bool
+}
+
+// boolPtr is a small helper for populating Entry.Snapshot, which is a
+// pointer so that omitempty can tell "false" from "not applicable".
+func boolPtr(b bool) *bool { return &b }
+
+// httpGet issues a context-aware GET, the low-level call every
+// provider's Versions/Resolve goes through, so cancelling ctx (e.g. a
+// deadline) actually aborts the request instead of leaving it to run to
+// completion.
+func httpGet(ctx context.Context, client *http.Client, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return client.Do(req)
+}
+
+// ErrSkip is returned by Resolve when a version exists upstream but
+// belongs to a channel the caller didn't ask for. It's not a failure:
+// callers should drop the version rather than recording it as an error.
+var ErrSkip = errors.New("providers: version skipped by channel filter")
+
+// Provider resolves available versions and download info for one server
+// or proxy distribution.
+type Provider interface {
+	// Name identifies the distribution, matching its key in
+	// ServiceVersions (e.g. "PAPER", "VANILLA").
+	Name() string
+	// Kind says whether this distribution belongs under PROXY or SERVER.
+	Kind() Kind
+	// Versions lists all known versions, newest first.
+	Versions(ctx context.Context) ([]string, error)
+	// Resolve fetches download info for a single version, including
+	// whatever checksum the upstream publishes and, if vopts.Enabled,
+	// one actually verified by downloading the artifact.
+	Resolve(ctx context.Context, version string) (Entry, error)
+}
+
+// Constructor builds a Provider bound to the given HTTP clients, verify
+// options and channel options. client is used for cacheable metadata
+// requests (version lists, build lookups); rawClient is used for
+// full-artifact downloads in Resolve, which must never be routed through
+// the metadata cache. Providers that only ever deal in releases (Purpur,
+// Fabric, ...) are free to ignore channels.
+type Constructor func(client, rawClient *http.Client, vopts VerifyOptions, channels ChannelOptions) Provider
+
+var registry = map[string]Constructor{}
+
+// Register adds a provider constructor under name. Call it from an
+// init() in the file that implements the provider. It panics on a
+// duplicate name, which can only happen from a programming error.
+func Register(name string, ctor Constructor) {
+	if _, exists := registry[name]; exists {
+		panic(fmt.Sprintf("providers: %s registered twice", name))
+	}
+	registry[name] = ctor
+}
+
+// New builds every registered provider, bound to client, rawClient,
+// vopts and channels, ordered by name for a deterministic refresh order.
+func New(client, rawClient *http.Client, vopts VerifyOptions, channels ChannelOptions) []Provider {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	out := make([]Provider, 0, len(names))
+	for _, name := range names {
+		out = append(out, registry[name](client, rawClient, vopts, channels))
+	}
+	return out
+}
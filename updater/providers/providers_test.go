@@ -0,0 +1,31 @@
+package providers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+)
+
+// redirectingClient returns an *http.Client whose requests are rewritten
+// to target srv regardless of the host/scheme a provider hardcodes, so
+// tests can exercise real Versions/Resolve code paths against a local
+// httptest.Server instead of the actual upstream.
+func redirectingClient(srv *httptest.Server) *http.Client {
+	target, err := url.Parse(srv.URL)
+	if err != nil {
+		panic(err)
+	}
+	return &http.Client{Transport: &redirectTransport{target: target}}
+}
+
+type redirectTransport struct {
+	target *url.URL
+}
+
+func (t *redirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme = t.target.Scheme
+	req.URL.Host = t.target.Host
+	req.Host = t.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
@@ -0,0 +1,130 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/ezTxmMC/rustnesscloud-manifest/updater/internal/mcver"
+	"github.com/ezTxmMC/rustnesscloud-manifest/updater/internal/verify"
+)
+
+func init() {
+	Register("PURPUR", func(client, rawClient *http.Client, vopts VerifyOptions, _ ChannelOptions) Provider {
+		return &purpurProvider{client: client, rawClient: rawClient, verify: vopts}
+	})
+}
+
+type purpurProvider struct {
+	client    *http.Client
+	rawClient *http.Client
+	verify    VerifyOptions
+}
+
+func (p *purpurProvider) Name() string { return "PURPUR" }
+func (p *purpurProvider) Kind() Kind   { return KindServer }
+
+func (p *purpurProvider) Versions(ctx context.Context) ([]string, error) {
+	var result struct {
+		Versions []string `json:"versions"`
+	}
+	resp, err := httpGet(ctx, p.client, "https://api.purpurmc.org/v2/purpur")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	mcver.SortDescending(result.Versions)
+	return result.Versions, nil
+}
+
+func (p *purpurProvider) latestBuild(ctx context.Context, version string) (url, build string, err error) {
+	api := fmt.Sprintf("https://api.purpurmc.org/v2/purpur/%s", version)
+	var result struct {
+		Builds struct {
+			Latest string `json:"latest"`
+		} `json:"builds"`
+	}
+	resp, err := httpGet(ctx, p.client, api)
+	if err != nil {
+		return "", "", err
+	}
+	defer resp.Body.Close()
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", "", err
+	}
+	if result.Builds.Latest == "" {
+		return "", "", fmt.Errorf("no latest build found for version %s", version)
+	}
+	url = fmt.Sprintf("https://api.purpurmc.org/v2/purpur/%s/%s/download", version, result.Builds.Latest)
+	return url, result.Builds.Latest, nil
+}
+
+func (p *purpurProvider) buildMD5(ctx context.Context, version, build string) (string, error) {
+	api := fmt.Sprintf("https://api.purpurmc.org/v2/purpur/%s/%s/hash", version, build)
+	resp, err := httpGet(ctx, p.client, api)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(body)), nil
+}
+
+// buildSignature fetches the detached minisign signature Purpur publishes
+// alongside each build, in the same request shape as buildMD5.
+func (p *purpurProvider) buildSignature(ctx context.Context, version, build string) (string, error) {
+	api := fmt.Sprintf("https://api.purpurmc.org/v2/purpur/%s/%s/signature", version, build)
+	resp, err := httpGet(ctx, p.client, api)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(body)), nil
+}
+
+func (p *purpurProvider) Resolve(ctx context.Context, version string) (Entry, error) {
+	url, build, err := p.latestBuild(ctx, version)
+	if err != nil {
+		return Entry{}, err
+	}
+
+	entry := Entry{URL: url}
+	if p.verify.Enabled {
+		md5hex, err := p.buildMD5(ctx, version, build)
+		if err != nil {
+			return Entry{}, fmt.Errorf("fetching md5: %w", err)
+		}
+		result, err := verify.Download(ctx, p.rawClient, url, verify.Expected{Algo: "md5", Hex: md5hex}, p.verify.MinisignPubkey != "")
+		if err != nil {
+			return Entry{}, err
+		}
+		entry.SHA256, entry.Size = result.SHA256, result.Size
+
+		if p.verify.MinisignPubkey == "" {
+			fmt.Printf("PURPUR %s: warning: skipping signature verification, no -minisign-pubkey configured\n", version)
+		} else {
+			sig, err := p.buildSignature(ctx, version, build)
+			if err != nil {
+				return Entry{}, fmt.Errorf("fetching signature: %w", err)
+			}
+			if err := verify.Minisign(p.verify.MinisignPubkey, sig, result.Body); err != nil {
+				return Entry{}, err
+			}
+			entry.Signature = sig
+		}
+	}
+	return entry, nil
+}
@@ -0,0 +1,96 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/ezTxmMC/rustnesscloud-manifest/updater/internal/mcver"
+	"github.com/ezTxmMC/rustnesscloud-manifest/updater/internal/verify"
+)
+
+func init() {
+	Register("SPONGE", func(client, rawClient *http.Client, vopts VerifyOptions, _ ChannelOptions) Provider {
+		return &spongeProvider{client: client, rawClient: rawClient, verify: vopts}
+	})
+}
+
+const spongeArtifactURL = "https://dl-api.spongepowered.org/v2/groups/org.spongepowered/artifacts/spongevanilla"
+
+type spongeProvider struct {
+	client    *http.Client
+	rawClient *http.Client
+	verify    VerifyOptions
+}
+
+func (p *spongeProvider) Name() string { return "SPONGE" }
+func (p *spongeProvider) Kind() Kind   { return KindServer }
+
+func (p *spongeProvider) Versions(ctx context.Context) ([]string, error) {
+	var result struct {
+		Artifacts map[string]struct{} `json:"artifacts"`
+	}
+	resp, err := httpGet(ctx, p.client, spongeArtifactURL+"/versions?tags=stable")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	versions := make([]string, 0, len(result.Artifacts))
+	for v := range result.Artifacts {
+		versions = append(versions, v)
+	}
+	mcver.SortDescending(versions)
+	return versions, nil
+}
+
+func (p *spongeProvider) Resolve(ctx context.Context, version string) (Entry, error) {
+	api := fmt.Sprintf("%s/versions/%s", spongeArtifactURL, version)
+	var result struct {
+		Assets []struct {
+			Classifier  string `json:"classifier"`
+			Extension   string `json:"extension"`
+			DownloadURL string `json:"downloadUrl"`
+			SHA256      string `json:"sha256"`
+			Size        int64  `json:"size"`
+		} `json:"assets"`
+	}
+	resp, err := httpGet(ctx, p.client, api)
+	if err != nil {
+		return Entry{}, err
+	}
+	defer resp.Body.Close()
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return Entry{}, err
+	}
+
+	var chosen *struct {
+		Classifier  string `json:"classifier"`
+		Extension   string `json:"extension"`
+		DownloadURL string `json:"downloadUrl"`
+		SHA256      string `json:"sha256"`
+		Size        int64  `json:"size"`
+	}
+	for i := range result.Assets {
+		if result.Assets[i].Extension == "jar" && result.Assets[i].Classifier == "universal" {
+			chosen = &result.Assets[i]
+			break
+		}
+	}
+	if chosen == nil {
+		return Entry{}, fmt.Errorf("no universal jar found for SpongeVanilla %s", version)
+	}
+
+	entry := Entry{URL: chosen.DownloadURL, SHA256: chosen.SHA256, Size: chosen.Size}
+	if p.verify.Enabled {
+		verified, err := verify.Download(ctx, p.rawClient, chosen.DownloadURL, verify.Expected{Algo: "sha256", Hex: chosen.SHA256}, false)
+		if err != nil {
+			return Entry{}, err
+		}
+		entry.SHA256, entry.Size = verified.SHA256, verified.Size
+	}
+	return entry, nil
+}
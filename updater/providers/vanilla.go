@@ -0,0 +1,134 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/ezTxmMC/rustnesscloud-manifest/updater/internal/mcver"
+	"github.com/ezTxmMC/rustnesscloud-manifest/updater/internal/verify"
+)
+
+const vanillaManifestURL = "https://piston-meta.mojang.com/mc/game/version_manifest_v2.json"
+
+func init() {
+	Register("VANILLA", func(client, rawClient *http.Client, vopts VerifyOptions, channels ChannelOptions) Provider {
+		return &vanillaProvider{client: client, rawClient: rawClient, verify: vopts, channels: channels}
+	})
+}
+
+// isSnapshotType reports whether a Mojang version "type" belongs to the
+// snapshot channel. Vanilla has no separate experimental channel, so
+// both weekly snapshots and old beta builds fall under Snapshot here.
+func isSnapshotType(t string) bool {
+	return t == "snapshot" || t == "old_beta"
+}
+
+type vanillaProvider struct {
+	client    *http.Client
+	rawClient *http.Client
+	verify    VerifyOptions
+	channels  ChannelOptions
+}
+
+func (p *vanillaProvider) Name() string { return "VANILLA" }
+func (p *vanillaProvider) Kind() Kind   { return KindServer }
+
+func (p *vanillaProvider) manifest(ctx context.Context) (versions []struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+	URL  string `json:"url"`
+}, err error) {
+	var result struct {
+		Versions []struct {
+			ID   string `json:"id"`
+			Type string `json:"type"`
+			URL  string `json:"url"`
+		} `json:"versions"`
+	}
+	resp, err := httpGet(ctx, p.client, vanillaManifestURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	return result.Versions, nil
+}
+
+// Liefert alle Vanilla-Versionen passend zu den gewählten Channels (neueste zuerst)
+func (p *vanillaProvider) Versions(ctx context.Context) ([]string, error) {
+	versions, err := p.manifest(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var selected []string
+	for _, v := range versions {
+		switch {
+		case v.Type == "release" && p.channels.Release:
+			selected = append(selected, v.ID)
+		case isSnapshotType(v.Type) && p.channels.Snapshot:
+			selected = append(selected, v.ID)
+		}
+	}
+	// Mojang's manifest is already newest-first, but don't rely on that
+	// implicitly - sort explicitly with the same comparator as everything
+	// else so a "latest" pick is always well-defined.
+	mcver.SortDescending(selected)
+	return selected, nil
+}
+
+func (p *vanillaProvider) Resolve(ctx context.Context, version string) (Entry, error) {
+	versions, err := p.manifest(ctx)
+	if err != nil {
+		return Entry{}, err
+	}
+	var versionURL string
+	var snapshot bool
+	for _, v := range versions {
+		if v.ID == version {
+			versionURL = v.URL
+			snapshot = isSnapshotType(v.Type)
+			break
+		}
+	}
+	if versionURL == "" {
+		return Entry{}, fmt.Errorf("version %s not found in manifest", version)
+	}
+
+	var versionManifest struct {
+		Downloads struct {
+			Server struct {
+				URL  string `json:"url"`
+				SHA1 string `json:"sha1"`
+				Size int64  `json:"size"`
+			} `json:"server"`
+		} `json:"downloads"`
+	}
+	resp, err := httpGet(ctx, p.client, versionURL)
+	if err != nil {
+		return Entry{}, err
+	}
+	defer resp.Body.Close()
+	if err := json.NewDecoder(resp.Body).Decode(&versionManifest); err != nil {
+		return Entry{}, err
+	}
+	if versionManifest.Downloads.Server.URL == "" {
+		return Entry{}, fmt.Errorf("no server download found for version %s", version)
+	}
+
+	entry := Entry{URL: versionManifest.Downloads.Server.URL, Size: versionManifest.Downloads.Server.Size}
+	if snapshot {
+		entry.Snapshot = boolPtr(true)
+	}
+	if p.verify.Enabled {
+		result, err := verify.Download(ctx, p.rawClient, entry.URL, verify.Expected{Algo: "sha1", Hex: versionManifest.Downloads.Server.SHA1}, false)
+		if err != nil {
+			return Entry{}, err
+		}
+		entry.SHA256, entry.Size = result.SHA256, result.Size
+	}
+	return entry, nil
+}
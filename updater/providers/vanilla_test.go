@@ -0,0 +1,48 @@
+package providers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+const vanillaManifestFixture = `{
+  "versions": [
+    {"id": "1.21", "type": "release", "url": "https://example.invalid/1.21.json"},
+    {"id": "24w10a", "type": "snapshot", "url": "https://example.invalid/24w10a.json"},
+    {"id": "b1.8", "type": "old_beta", "url": "https://example.invalid/b1.8.json"}
+  ]
+}`
+
+func TestVanillaProvider_Versions_ChannelFiltering(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(vanillaManifestFixture))
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name     string
+		channels ChannelOptions
+		want     []string
+	}{
+		{"release only", ChannelOptions{Release: true}, []string{"1.21"}},
+		{"snapshot only", ChannelOptions{Snapshot: true}, []string{"24w10a", "b1.8"}},
+		{"release and snapshot", ChannelOptions{Release: true, Snapshot: true}, []string{"24w10a", "1.21", "b1.8"}},
+		{"nothing enabled", ChannelOptions{}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &vanillaProvider{client: redirectingClient(srv), channels: tt.channels}
+			got, err := p.Versions(context.Background())
+			if err != nil {
+				t.Fatalf("Versions returned error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Versions = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}